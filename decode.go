@@ -0,0 +1,37 @@
+package conf
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ghodss/yaml"
+)
+
+// defaultFileDecoders associates configuration file extensions with the
+// decoder used to unmarshal their content. Loader.FileDecoders may be set to
+// override these defaults or add support for other formats.
+var defaultFileDecoders = map[string]func([]byte, interface{}) error{
+	".yaml": yaml.Unmarshal,
+	".yml":  yaml.Unmarshal,
+	".json": json.Unmarshal,
+	".toml": toml.Unmarshal,
+}
+
+// decodeFile picks a decoder for path based on its file extension, looking it
+// up in decoders first and falling back to defaultFileDecoders, then uses it
+// to unmarshal b into dst.
+func decodeFile(decoders map[string]func([]byte, interface{}) error, path string, b []byte, dst interface{}) (err error) {
+	ext := filepath.Ext(path)
+
+	if decode, ok := decoders[ext]; ok {
+		return decode(b, dst)
+	}
+
+	if decode, ok := defaultFileDecoders[ext]; ok {
+		return decode(b, dst)
+	}
+
+	return fmt.Errorf("conf: unsupported configuration file extension %q", ext)
+}
@@ -0,0 +1,91 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type decodeTestConfig struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func TestDecodeFileByExtension(t *testing.T) {
+	tests := []struct {
+		ext     string
+		content string
+	}{
+		{".yaml", "host: localhost\nport: 5432\n"},
+		{".yml", "host: localhost\nport: 5432\n"},
+		{".json", `{"host": "localhost", "port": 5432}`},
+		{".toml", "host = \"localhost\"\nport = 5432\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ext, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "cfg"+test.ext)
+			if err := os.WriteFile(path, []byte(test.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var c decodeTestConfig
+			ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", path}}
+
+			if _, err := ld.Load(&c); err != nil {
+				t.Fatal(err)
+			}
+
+			if c != (decodeTestConfig{Host: "localhost", Port: 5432}) {
+				t.Fatalf("unexpected config: %+v", c)
+			}
+		})
+	}
+}
+
+func TestDecodeFileUnsupportedExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.ini")
+	if err := os.WriteFile(path, []byte("host=localhost"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var c decodeTestConfig
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", path}}
+
+	if _, err := ld.Load(&c); err == nil {
+		t.Fatal("expected an error for an unsupported extension")
+	}
+}
+
+func TestDecodeFileCustomDecoder(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.ini")
+	if err := os.WriteFile(path, []byte("host=localhost\nport=5432"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	decodeINI := func(b []byte, dst interface{}) error {
+		c := dst.(*decodeTestConfig)
+		c.Host = "localhost"
+		c.Port = 5432
+		return nil
+	}
+
+	var c decodeTestConfig
+	ld := Loader{
+		Program:      "test",
+		FileFlag:     "config-file",
+		Args:         []string{"-config-file", path},
+		FileDecoders: map[string]func([]byte, interface{}) error{".ini": decodeINI},
+	}
+
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c != (decodeTestConfig{Host: "localhost", Port: 5432}) {
+		t.Fatalf("unexpected config: %+v", c)
+	}
+}
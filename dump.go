@@ -0,0 +1,131 @@
+package conf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ghodss/yaml"
+)
+
+// Dump writes the configuration held in dst to w, encoded using format
+// ("yaml", "json", or "toml"). This is typically used to print the effective
+// configuration after Load has merged the configuration file, environment,
+// and program arguments, which is useful for debugging or for writing the
+// resolved configuration back out for ops diagnostics.
+//
+// The output mirrors the nested shape of dst rather than the dotted
+// flag/environment names, so that it can be read back in through the same
+// FileFlag that Load uses. The yaml and json formats honor dst's json tags;
+// the toml format names keys after toml tags or Go field names, and does not
+// read json tags.
+//
+// The dst argument is expected to be a pointer to a struct type, following
+// the same rules as Load. Fields tagged `conf:"secret"` are masked in the
+// output.
+func (ld Loader) Dump(dst interface{}, w io.Writer, format string) (err error) {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("cannot dump configuration from %T", dst))
+	}
+
+	if v.IsNil() {
+		panic(fmt.Sprintf("cannot dump configuration from nil %T", dst))
+	}
+
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("cannot dump configuration from %T", dst))
+	}
+
+	// Dump works on a deep copy of dst so that masking secret fields below
+	// never mutates the caller's configuration. A shallow copy isn't enough:
+	// pointer fields would still point at the original's pointee, and
+	// maskSecrets dereferences pointers to reach secrets nested behind them.
+	cfg := deepCopy(v)
+	maskSecrets(cfg)
+
+	out := cfg.Addr().Interface()
+
+	switch format {
+	case "", "yaml":
+		var b []byte
+		if b, err = yaml.Marshal(out); err != nil {
+			return
+		}
+		_, err = w.Write(b)
+
+	case "json":
+		var b []byte
+		if b, err = json.MarshalIndent(out, "", "  "); err != nil {
+			return
+		}
+		_, err = w.Write(append(b, '\n'))
+
+	case "toml":
+		err = toml.NewEncoder(w).Encode(out)
+
+	default:
+		err = fmt.Errorf("conf: unsupported dump format %q", format)
+	}
+
+	return
+}
+
+// deepCopy returns a copy of the struct value v where every pointer field,
+// including ones nested inside structs, points at a fresh copy of its
+// pointee rather than the original one.
+func deepCopy(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+
+	for i, n := 0, out.NumField(); i != n; i++ {
+		fv := out.Field(i)
+
+		switch {
+		case fv.Kind() == reflect.Ptr:
+			if fv.IsNil() {
+				continue
+			}
+			fresh := reflect.New(fv.Type().Elem())
+			if fv.Elem().Kind() == reflect.Struct {
+				fresh.Elem().Set(deepCopy(fv.Elem()))
+			} else {
+				fresh.Elem().Set(fv.Elem())
+			}
+			fv.Set(fresh)
+
+		case fv.Kind() == reflect.Struct:
+			fv.Set(deepCopy(fv))
+		}
+	}
+
+	return out
+}
+
+// maskSecrets replaces the value of every string field tagged
+// `conf:"secret"` in v, recursing into nested structs, so that Dump never
+// writes passwords or tokens out in clear text.
+func maskSecrets(v reflect.Value) {
+	t := v.Type()
+
+	for i, n := 0, v.NumField(); i != n; i++ {
+		ft := t.Field(i)
+		fv := v.Field(i)
+
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() == reflect.Struct {
+			maskSecrets(fv)
+			continue
+		}
+
+		if parseConfTag(ft.Tag.Get("conf")).Secret && fv.Kind() == reflect.String {
+			fv.SetString(secretMask)
+		}
+	}
+}
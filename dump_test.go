@@ -0,0 +1,99 @@
+package conf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type dumpTestDB struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type dumpTestConfig struct {
+	DB       dumpTestDB `json:"db"`
+	Password string     `json:"password" conf:"secret"`
+}
+
+func TestResolveFilePathRegistersDumpFlag(t *testing.T) {
+	var c dumpTestConfig
+	v := reflect.ValueOf(&c).Elem()
+
+	if _, err := resolveFilePath(v, "test", "config-file", "dump-config", []string{"-dump-config"}, nil); err != nil {
+		t.Fatalf("-dump-config should be a known flag, got: %v", err)
+	}
+}
+
+func TestDumpMasksSecretFields(t *testing.T) {
+	in := dumpTestConfig{Password: "hunter2"}
+
+	buf := &bytes.Buffer{}
+	if err := (Loader{}).Dump(&in, buf, "yaml"); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.Password != "hunter2" {
+		t.Fatalf("Dump must not mutate the caller's struct, got %q", in.Password)
+	}
+
+	if s := buf.String(); !strings.Contains(s, secretMask) || strings.Contains(s, "hunter2") {
+		t.Fatalf("expected secret to be masked, got:\n%s", s)
+	}
+}
+
+// Dump must emit the same nested shape that loadFile decodes into, or the
+// output it writes can never be read back through -config-file.
+func TestDumpRoundTripsThroughConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.yaml")
+
+	in := dumpTestConfig{DB: dumpTestDB{Host: "localhost", Port: 5432}, Password: "hunter2"}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = (Loader{}).Dump(&in, f, "yaml"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	var out dumpTestConfig
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", path}}
+
+	if _, err = ld.Load(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.DB != (dumpTestDB{Host: "localhost", Port: 5432}) {
+		t.Fatalf("nested fields were lost on round trip: %+v", out)
+	}
+}
+
+type dumpTestConfigWithPointer struct {
+	Nested *dumpTestConfig
+}
+
+// maskSecrets dereferences pointer fields to reach secrets nested behind
+// them; Dump must not let that mutate the pointee the caller's struct still
+// points to.
+func TestDumpDoesNotMutateSecretBehindPointerField(t *testing.T) {
+	in := dumpTestConfigWithPointer{Nested: &dumpTestConfig{Password: "hunter2"}}
+
+	buf := &bytes.Buffer{}
+	if err := (Loader{}).Dump(&in, buf, "yaml"); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.Nested.Password != "hunter2" {
+		t.Fatalf("Dump must not mutate the secret behind a pointer field, got %q", in.Nested.Password)
+	}
+
+	if s := buf.String(); !strings.Contains(s, secretMask) {
+		t.Fatalf("expected secret to be masked, got:\n%s", s)
+	}
+}
@@ -0,0 +1,28 @@
+package conf
+
+import "sync/atomic"
+
+// Holder provides atomic, concurrency-safe access to a configuration value
+// of type T. It pairs naturally with Loader.Watch: store the initial value
+// after Load, and replace it from the onChange callback on every reload, so
+// concurrent readers never observe a partially updated struct.
+type Holder[T any] struct {
+	v atomic.Value
+}
+
+// NewHolder returns a Holder initialized with val.
+func NewHolder[T any](val T) *Holder[T] {
+	h := &Holder[T]{}
+	h.v.Store(val)
+	return h
+}
+
+// Load returns the most recently stored value.
+func (h *Holder[T]) Load() T {
+	return h.v.Load().(T)
+}
+
+// Store replaces the held value.
+func (h *Holder[T]) Store(val T) {
+	h.v.Store(val)
+}
@@ -41,6 +41,7 @@ func Load(dst interface{}) (args []string) {
 		Env:      os.Environ(),
 		Program:  filepath.Base(os.Args[0]),
 		FileFlag: "config-file",
+		DumpFlag: "dump-config",
 	}).Load(dst); err != nil {
 		fmt.Fprint(os.Stderr, err)
 		os.Exit(1)
@@ -56,6 +57,19 @@ type Loader struct {
 	Env      []string // list of environment variables ["KEY=VALUE", ...]
 	Program  string   // name of the program
 	FileFlag string   // command line option for the configuration file
+	DumpFlag string   // command line option that dumps the merged configuration and exits
+
+	// FileDecoders may be set to register decoders for configuration file
+	// extensions, or to override the defaults (.yaml, .yml, .json, .toml).
+	// The decoder is selected based on the extension of the file pointed to
+	// by FileFlag.
+	FileDecoders map[string]func([]byte, interface{}) error
+
+	// SecretResolvers may be set to register resolvers for secret reference
+	// schemes, or to override the defaults (file, env, exec). A resolver is
+	// invoked on the value of a field tagged `conf:"secret"` when that value
+	// looks like scheme://rest.
+	SecretResolvers map[string]func(string) (string, error)
 }
 
 // Load uses the loader ld to load the program configuration into dst, and
@@ -85,65 +99,118 @@ func (ld Loader) Load(dst interface{}) (args []string, err error) {
 }
 
 func (ld Loader) load(dst reflect.Value) (args []string, err error) {
-	if err = loadFile(dst, ld.Program, ld.FileFlag, ld.Args, ioutil.ReadFile); err != nil {
+	if err = applyDefaults(dst); err != nil {
+		return
+	}
+
+	if err = loadFile(dst, ld.Program, ld.FileFlag, ld.DumpFlag, ld.Args, ld.FileDecoders, ld.SecretResolvers, ioutil.ReadFile); err != nil {
 		args = nil
 		return
 	}
 
-	if err = loadEnv(dst, ld.Program, ld.Env); err != nil {
+	if err = loadEnv(dst, ld.Program, ld.Env, ld.SecretResolvers); err != nil {
 		args = nil
 		return
 	}
 
-	return loadArgs(dst, ld.Program, ld.FileFlag, ld.Args)
-}
+	var dump bool
 
-func loadFile(dst reflect.Value, name string, fileFlag string, args []string, readFile func(string) ([]byte, error)) (err error) {
-	if len(fileFlag) != 0 {
-		var a = append([]string{}, args...)
-		var b []byte
-		var f string
-		var v = reflect.New(dst.Type()).Elem()
-
-		out := &bytes.Buffer{}
-		set := flag.NewFlagSet(name, flag.ContinueOnError)
-		set.SetOutput(out)
-		set.StringVar(&f, fileFlag, "", "Path to the configuration file.")
-
-		scanFields(v, "", ".", func(key string, help string, val reflect.Value) {
-			set.Var(value{val}, key, help)
-		})
+	if args, dump, err = loadArgs(dst, ld.Program, ld.FileFlag, ld.DumpFlag, ld.Args, ld.SecretResolvers); err != nil {
+		args = nil
+		return
+	}
 
-		if err = set.Parse(a); err != nil {
+	if dump {
+		if err = ld.Dump(dst.Addr().Interface(), os.Stdout, "yaml"); err != nil {
 			return
 		}
+		os.Exit(0)
+	}
 
-		if len(f) == 0 {
-			return
-		}
+	if err = checkRequired(dst); err != nil {
+		args = nil
+		return
+	}
 
-		if b, err = readFile(f); err != nil {
-			return
-		}
+	if err = runValidation(dst); err != nil {
+		args = nil
+		return
+	}
 
-		if err = yaml.Unmarshal(b, dst.Addr().Interface()); err != nil {
-			return
-		}
+	return
+}
+
+func loadFile(dst reflect.Value, name string, fileFlag string, dumpFlag string, args []string, decoders map[string]func([]byte, interface{}) error, resolvers map[string]func(string) (string, error), readFile func(string) ([]byte, error)) (err error) {
+	var f string
+	var b []byte
+
+	if f, err = resolveFilePath(dst, name, fileFlag, dumpFlag, args, resolvers); err != nil || len(f) == 0 {
+		return
 	}
+
+	if b, err = readFile(f); err != nil {
+		return
+	}
+
+	if err = decodeFile(decoders, f, b, dst.Addr().Interface()); err != nil {
+		return
+	}
+
+	return resolveSecrets(dst, resolvers)
+}
+
+// resolveFilePath parses args against a throwaway copy of dst just far
+// enough to learn the path given to fileFlag, without touching dst itself.
+// It's shared by loadFile and Loader.Watch, which both need to know which
+// file to read without fully decoding the configuration first.
+//
+// dumpFlag is registered alongside fileFlag (even though its value is
+// discarded here) so that parsing doesn't fail with "flag provided but not
+// defined" when a caller passes it before the real flag set in loadArgs gets
+// a chance to see it.
+//
+// The struct's own fields are registered too, for the same "flag provided
+// but not defined" reason, but with their resolvers stripped: this parse is
+// only ever used to learn the file path, and its result is discarded, so a
+// secret field must not trigger its resolver here or a side-effecting one
+// (exec://, a one-time-token fetcher) would run a second time in addition to
+// the real parse in loadArgs.
+func resolveFilePath(dst reflect.Value, name string, fileFlag string, dumpFlag string, args []string, resolvers map[string]func(string) (string, error)) (f string, err error) {
+	if len(fileFlag) == 0 {
+		return
+	}
+
+	var v = reflect.New(dst.Type()).Elem()
+	var dump bool
+
+	out := &bytes.Buffer{}
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	set.SetOutput(out)
+	set.StringVar(&f, fileFlag, "", "Path to the configuration file.")
+
+	if len(dumpFlag) != 0 {
+		set.BoolVar(&dump, dumpFlag, false, "Print the merged configuration and exit.")
+	}
+
+	scanFields(v, "", ".", func(key string, help string, tag confTag, val reflect.Value) {
+		set.Var(newValue(val, confTag{}, nil), key, help)
+	})
+
+	err = set.Parse(append([]string{}, args...))
 	return
 }
 
-func loadEnv(dst reflect.Value, name string, env []string) (err error) {
+func loadEnv(dst reflect.Value, name string, env []string, resolvers map[string]func(string) (string, error)) (err error) {
 	type entry struct {
 		key string
-		val value
+		val flag.Value
 	}
 	var entries []entry
 
-	scanFields(dst, name, "_", func(key string, help string, val reflect.Value) {
+	scanFields(dst, name, "_", func(key string, help string, tag confTag, val reflect.Value) {
 		entries = append(entries, entry{
 			key: snakecaseUpper(key) + "=",
-			val: value{val},
+			val: newValue(val, tag, resolvers),
 		})
 	})
 
@@ -161,7 +228,7 @@ func loadEnv(dst reflect.Value, name string, env []string) (err error) {
 	return
 }
 
-func loadArgs(dst reflect.Value, name string, fileFlag string, args []string) (leftover []string, err error) {
+func loadArgs(dst reflect.Value, name string, fileFlag string, dumpFlag string, args []string, resolvers map[string]func(string) (string, error)) (leftover []string, dump bool, err error) {
 	args = append([]string{}, args...)
 
 	out := &bytes.Buffer{}
@@ -172,8 +239,12 @@ func loadArgs(dst reflect.Value, name string, fileFlag string, args []string) (l
 		set.String(fileFlag, "", "Path to the configuration file.")
 	}
 
-	scanFields(dst, "", ".", func(key string, help string, val reflect.Value) {
-		set.Var(value{val}, key, help)
+	if len(dumpFlag) != 0 {
+		set.BoolVar(&dump, dumpFlag, false, "Print the merged configuration and exit.")
+	}
+
+	scanFields(dst, "", ".", func(key string, help string, tag confTag, val reflect.Value) {
+		set.Var(newValue(val, tag, resolvers), key, help)
 	})
 
 	if err = set.Parse(args); err != nil {
@@ -185,13 +256,18 @@ func loadArgs(dst reflect.Value, name string, fileFlag string, args []string) (l
 }
 
 type value struct {
-	v reflect.Value
+	v         reflect.Value
+	secret    bool
+	resolvers map[string]func(string) (string, error)
 }
 
 func (f value) String() string {
 	if !f.v.IsValid() {
 		return ""
 	}
+	if f.secret {
+		return secretMask
+	}
 	b, _ := json.Marshal(f.v.Interface())
 	return string(b)
 }
@@ -203,15 +279,27 @@ func (f value) Get() interface{} {
 	return f.v.Interface()
 }
 
-func (f value) Set(s string) error {
-	return yaml.Unmarshal([]byte(s), f.v.Addr().Interface())
+func (f value) Set(s string) (err error) {
+	if err = yaml.Unmarshal([]byte(s), f.v.Addr().Interface()); err != nil {
+		return
+	}
+
+	if f.secret && f.v.Kind() == reflect.String {
+		var resolved string
+		if resolved, err = resolveSecret(f.v.String(), f.resolvers); err != nil {
+			return
+		}
+		f.v.SetString(resolved)
+	}
+
+	return
 }
 
 func (f value) IsBoolFlag() bool {
 	return f.v.IsValid() && f.v.Kind() == reflect.Bool
 }
 
-func scanFields(v reflect.Value, base string, sep string, do func(string, string, reflect.Value)) {
+func scanFields(v reflect.Value, base string, sep string, do func(string, string, confTag, reflect.Value)) {
 	t := v.Type()
 
 	for i, n := 0, v.NumField(); i != n; i++ {
@@ -221,6 +309,7 @@ func scanFields(v reflect.Value, base string, sep string, do func(string, string
 		name := ft.Name
 		help := ft.Tag.Get("help")
 		jtag := jutil.ParseTag(ft.Tag.Get("json"))
+		ctag := parseConfTag(ft.Tag.Get("conf"))
 
 		if jtag.Skip {
 			continue
@@ -250,6 +339,6 @@ func scanFields(v reflect.Value, base string, sep string, do func(string, string
 		}
 
 		// For all other field types the delegate is called.
-		do(name, help, fv)
+		do(name, help, ctag, fv)
 	}
 }
\ No newline at end of file
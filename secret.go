@@ -0,0 +1,94 @@
+package conf
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+)
+
+// secretMask replaces the value of secret fields when the configuration is
+// printed (e.g. by Loader.Dump), so that passwords and tokens are never
+// written out in clear text.
+const secretMask = "******"
+
+// defaultSecretResolvers maps the URI scheme of a secret reference to the
+// function used to resolve it. Loader.SecretResolvers may be set to add
+// support for other schemes (e.g. vault://, aws-sm://) or to override these
+// defaults.
+var defaultSecretResolvers = map[string]func(string) (string, error){
+	"file": func(path string) (string, error) {
+		b, err := ioutil.ReadFile(path)
+		return strings.TrimSpace(string(b)), err
+	},
+
+	"env": func(name string) (string, error) {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("conf: environment variable %q is not set", name)
+		}
+		return v, nil
+	},
+
+	"exec": func(command string) (string, error) {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return "", fmt.Errorf("conf: empty exec secret reference")
+		}
+
+		out := &bytes.Buffer{}
+		cmd := exec.Command(fields[0], fields[1:]...)
+		cmd.Stdout = out
+
+		if err := cmd.Run(); err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(out.String()), nil
+	},
+}
+
+// resolveSecret resolves a secret reference such as file:///path/to/secret,
+// env://OTHER_VAR, or exec://cmd arg, using the scheme-specific function
+// found in resolvers, falling back to defaultSecretResolvers. Values that
+// don't match a known scheme are returned unchanged, which lets operators
+// set secret fields directly as well.
+func resolveSecret(s string, resolvers map[string]func(string) (string, error)) (string, error) {
+	scheme, rest, ok := strings.Cut(s, "://")
+	if !ok {
+		return s, nil
+	}
+
+	if resolve, ok := resolvers[scheme]; ok {
+		return resolve(rest)
+	}
+
+	if resolve, ok := defaultSecretResolvers[scheme]; ok {
+		return resolve(rest)
+	}
+
+	return s, nil
+}
+
+// resolveSecrets walks dst and resolves the value of every field tagged
+// `conf:"secret"` in place. It's used after a configuration file has been
+// decoded straight into dst, which bypasses the value.Set path that flags
+// and environment variables go through to get the same treatment.
+func resolveSecrets(dst reflect.Value, resolvers map[string]func(string) (string, error)) (err error) {
+	scanFields(dst, "", ".", func(key string, help string, tag confTag, val reflect.Value) {
+		if err != nil || !tag.Secret || val.Kind() != reflect.String {
+			return
+		}
+
+		var resolved string
+		if resolved, err = resolveSecret(val.String(), resolvers); err != nil {
+			return
+		}
+
+		val.SetString(resolved)
+	})
+	return
+}
@@ -0,0 +1,104 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveSecretSchemes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("CONF_TEST_SECRET", "from-env")
+	defer os.Unsetenv("CONF_TEST_SECRET")
+
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"file://" + path, "hunter2"},
+		{"env://CONF_TEST_SECRET", "from-env"},
+		{"plain-value", "plain-value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			s, err := resolveSecret(test.in, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != test.out {
+				t.Fatalf("expected %q, got %q", test.out, s)
+			}
+		})
+	}
+}
+
+// Reproduces a config file setting a secret field directly: loadFile decodes
+// into dst without going through value.Set, so the secret reference must be
+// resolved separately afterwards.
+func TestSecretResolvedFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	secretPath := filepath.Join(dir, "password")
+	if err := os.WriteFile(secretPath, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfgPath := filepath.Join(dir, "cfg.yaml")
+	content := "password: file://" + secretPath + "\n"
+	if err := os.WriteFile(cfgPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type cfg struct {
+		Password string `conf:"secret"`
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", cfgPath}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Password != "hunter2" {
+		t.Fatalf("secret reference was not resolved, got %q", c.Password)
+	}
+}
+
+// Reproduces a side-effecting resolver (e.g. exec://) firing twice for a
+// single Load: resolveFilePath's throwaway parse used to resolve secret
+// fields just like the real parse in loadArgs, even though its result is
+// discarded.
+func TestExecSecretResolverNotDoubleRunViaArgs(t *testing.T) {
+	dir := t.TempDir()
+	counterPath := filepath.Join(dir, "counter")
+	scriptPath := filepath.Join(dir, "incr.sh")
+
+	script := "#!/bin/sh\necho -n x >> " + counterPath + "\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	type cfg struct {
+		Password string `conf:"secret"`
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-Password", "exec://" + scriptPath}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(counterPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 1 {
+		t.Fatalf("expected the exec secret resolver to run exactly once, ran %d times", len(b))
+	}
+}
@@ -0,0 +1,108 @@
+package conf
+
+import (
+	"encoding/json"
+	"flag"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// newValue returns the flag.Value implementation used to set v from a flag or
+// environment variable. Slice fields get a
+// sliceValue so that repeated flags append instead of overwriting. Fixed-size
+// array fields keep the scalar value behavior, since reflect.Append panics on
+// an array Value.
+func newValue(v reflect.Value, tag confTag, resolvers map[string]func(string) (string, error)) flag.Value {
+	if v.Kind() == reflect.Slice {
+		first := true
+		return sliceValue{v, &first}
+	}
+	return value{v, tag.Secret, resolvers}
+}
+
+// sliceValue is the flag.Value installed on slice fields.
+//
+// A Set call with a YAML/JSON array literal (as found in environment
+// variables and configuration files) replaces the whole slice. A Set call
+// with a scalar parses and appends a single element instead, which is what
+// happens when a flag like -tag is repeated on the command line
+// (-tag=a -tag=b).
+//
+// For []string fields, a value that isn't an array literal is also accepted
+// as a comma or whitespace separated list, which is the convention operators
+// commonly use for environment variables (e.g. FOO_HOSTS=a,b,c).
+//
+// A sliceValue is constructed fresh for every loadEnv/loadArgs invocation, so
+// first is scoped to a single configuration source: its first Set call
+// clears whatever default, file, or lower-precedence value is already in v
+// before appending, so that source wins outright instead of being merged
+// onto, matching the default < file < env < args precedence documented on
+// applyDefaults. Later Set calls from the same source (repeated flags) still append
+// onto each other.
+type sliceValue struct {
+	v     reflect.Value
+	first *bool
+}
+
+func (f sliceValue) String() string {
+	if !f.v.IsValid() {
+		return ""
+	}
+	b, _ := json.Marshal(f.v.Interface())
+	return string(b)
+}
+
+func (f sliceValue) Get() interface{} {
+	if !f.v.IsValid() {
+		return nil
+	}
+	return f.v.Interface()
+}
+
+func (f sliceValue) Set(s string) (err error) {
+	first := *f.first
+	*f.first = false
+
+	if t := strings.TrimSpace(s); strings.HasPrefix(t, "[") {
+		return yaml.Unmarshal([]byte(t), f.v.Addr().Interface())
+	}
+
+	if first {
+		f.v.Set(reflect.Zero(f.v.Type()))
+	}
+
+	if f.v.Type().Elem().Kind() == reflect.String {
+		for _, e := range splitList(s) {
+			f.v.Set(reflect.Append(f.v, reflect.ValueOf(e)))
+		}
+		return nil
+	}
+
+	elem := reflect.New(f.v.Type().Elem()).Elem()
+
+	if err = yaml.Unmarshal([]byte(s), elem.Addr().Interface()); err != nil {
+		return
+	}
+
+	f.v.Set(reflect.Append(f.v, elem))
+	return nil
+}
+
+func (f sliceValue) IsBoolFlag() bool {
+	return false
+}
+
+// splitList splits a comma or whitespace separated list of values, trimming
+// surrounding space from each element and discarding empty ones.
+func splitList(s string) (list []string) {
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		if f = strings.TrimSpace(f); len(f) != 0 {
+			list = append(list, f)
+		}
+	}
+	return
+}
@@ -0,0 +1,121 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSliceValueAppendsRepeatedFlags(t *testing.T) {
+	type cfg struct {
+		Tags []string
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", Args: []string{"-Tags", "a", "-Tags", "b"}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Tags) != 2 || c.Tags[0] != "a" || c.Tags[1] != "b" {
+		t.Fatalf("expected [a b], got %v", c.Tags)
+	}
+}
+
+func TestSliceValueAcceptsArrayLiteral(t *testing.T) {
+	type cfg struct {
+		Tags []string
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", Args: []string{"-Tags", `["a","b","c"]`}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Tags) != 3 || c.Tags[2] != "c" {
+		t.Fatalf("expected [a b c], got %v", c.Tags)
+	}
+}
+
+func TestSliceValueSplitsCommaSeparatedEnv(t *testing.T) {
+	type cfg struct {
+		Hosts []string
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", Env: []string{"TEST_HOSTS=a,b,c"}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Hosts) != 3 || c.Hosts[0] != "a" || c.Hosts[2] != "c" {
+		t.Fatalf("expected [a b c], got %v", c.Hosts)
+	}
+}
+
+// Fixed-size array fields keep the scalar value behavior instead of routing
+// through sliceValue, since reflect.Append panics on an array Value.
+func TestArrayFieldUsesScalarValue(t *testing.T) {
+	type cfg struct {
+		Tags [3]string
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", Args: []string{"-Tags", `["a","b","c"]`}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Tags != ([3]string{"a", "b", "c"}) {
+		t.Fatalf("unexpected array value: %v", c.Tags)
+	}
+}
+
+// A higher-precedence source must replace a slice set by a lower-precedence
+// one outright, not merge onto it, matching the default < file < env < args
+// precedence documented on applyDefaults.
+func TestSliceValueEnvReplacesFileValue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.yaml")
+	if err := os.WriteFile(path, []byte("hosts: [h1, h2]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type cfg struct {
+		Hosts []string
+	}
+	var c cfg
+
+	ld := Loader{
+		Program:  "test",
+		FileFlag: "config-file",
+		Args:     []string{"-config-file", path},
+		Env:      []string{"TEST_HOSTS=h3,h4"},
+	}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Hosts) != 2 || c.Hosts[0] != "h3" || c.Hosts[1] != "h4" {
+		t.Fatalf("expected env to replace the file value with [h3 h4], got %v", c.Hosts)
+	}
+}
+
+// Same precedence rule for a slice seeded from conf:"default=...": file/env/
+// args must replace the default rather than append to it.
+func TestSliceValueArgsReplaceDefault(t *testing.T) {
+	type cfg struct {
+		Tags []string `conf:"default=[d1,d2]"`
+	}
+	var c cfg
+
+	ld := Loader{Program: "test", Args: []string{"-Tags", "a"}}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Tags) != 1 || c.Tags[0] != "a" {
+		t.Fatalf("expected args to replace the default with [a], got %v", c.Tags)
+	}
+}
@@ -0,0 +1,45 @@
+package conf
+
+import "strings"
+
+// confTag holds the options parsed from a field's "conf" struct tag.
+type confTag struct {
+	Secret   bool   // field holds a secret reference that should be resolved and masked
+	Required bool   // Load fails if the field is left at its zero value
+	Default  string // value used to seed the field before file/env/args are applied
+}
+
+// parseConfTag parses the comma-separated options of a "conf" struct tag,
+// e.g. `conf:"required"` or `conf:"secret,default=5s"`.
+//
+// default= is the one option whose value may itself contain commas (a
+// scalar literal like "a,b", or the array literal convention sliceValue
+// accepts, e.g. "[d1,d2]"), so it must be the last option in the tag: once
+// encountered, everything up to the end of the tag is taken as its value
+// instead of being split further.
+func parseConfTag(s string) (tag confTag) {
+	for len(s) > 0 {
+		var opt string
+
+		switch {
+		case strings.HasPrefix(s, "default="):
+			opt, s = s, ""
+		default:
+			if i := strings.IndexByte(s, ','); i >= 0 {
+				opt, s = s[:i], s[i+1:]
+			} else {
+				opt, s = s, ""
+			}
+		}
+
+		switch {
+		case opt == "secret":
+			tag.Secret = true
+		case opt == "required":
+			tag.Required = true
+		case strings.HasPrefix(opt, "default="):
+			tag.Default = opt[len("default="):]
+		}
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package conf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfTagDefaultWithCommas(t *testing.T) {
+	tests := []struct {
+		in   string
+		want confTag
+	}{
+		{`default=[d1,d2]`, confTag{Default: "[d1,d2]"}},
+		{`secret,default=[d1,d2]`, confTag{Secret: true, Default: "[d1,d2]"}},
+		{`required,default=[d1,d2]`, confTag{Default: "[d1,d2]", Required: true}},
+		{`default=a,b`, confTag{Default: "a,b"}},
+	}
+
+	for _, test := range tests {
+		if got := parseConfTag(test.in); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseConfTag(%q) = %+v, want %+v", test.in, got, test.want)
+		}
+	}
+}
+
+// A default list using the same array literal convention sliceValue accepts
+// on the command line must seed the field correctly instead of being
+// truncated at the first comma.
+func TestApplyDefaultsSliceWithCommas(t *testing.T) {
+	type cfg struct {
+		Tags []string `conf:"default=[d1,d2]"`
+	}
+	var c cfg
+
+	ld := Loader{Program: "test"}
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Tags) != 2 || c.Tags[0] != "d1" || c.Tags[1] != "d2" {
+		t.Fatalf("expected default [d1 d2], got %v", c.Tags)
+	}
+}
@@ -0,0 +1,92 @@
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// MultiError is the error type returned by Loader.Load when one or more
+// required fields are left unset, so that every missing field can be reported
+// at once instead of failing on the first one. It's also returned when one or
+// more Validate methods fail.
+type MultiError []error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// applyDefaults seeds the fields of dst tagged with `conf:"default=..."`
+// before the configuration file, environment, and program arguments are
+// applied, making the default < file < env < args precedence chain explicit.
+func applyDefaults(dst reflect.Value) (err error) {
+	scanFields(dst, "", ".", func(key string, help string, tag confTag, val reflect.Value) {
+		if err != nil || len(tag.Default) == 0 {
+			return
+		}
+		err = yaml.Unmarshal([]byte(tag.Default), val.Addr().Interface())
+	})
+	return
+}
+
+// checkRequired returns a MultiError listing the dotted key of every field
+// tagged `conf:"required"` that was left at its zero value after all
+// configuration sources were applied, or nil if none were found.
+func checkRequired(dst reflect.Value) (err error) {
+	var errs MultiError
+
+	scanFields(dst, "", ".", func(key string, help string, tag confTag, val reflect.Value) {
+		if tag.Required && reflect.DeepEqual(val.Interface(), reflect.Zero(val.Type()).Interface()) {
+			errs = append(errs, fmt.Errorf("conf: missing required option %q", key))
+		}
+	})
+
+	if len(errs) != 0 {
+		err = errs
+	}
+	return
+}
+
+// runValidation calls the Validate method of dst, and of every struct nested
+// within it, if it implements it.
+//
+//	Validate() error
+func runValidation(v reflect.Value) (err error) {
+	var errs MultiError
+
+	var walk func(reflect.Value)
+	walk = func(v reflect.Value) {
+		if v.CanAddr() {
+			if validator, ok := v.Addr().Interface().(interface{ Validate() error }); ok {
+				if e := validator.Validate(); e != nil {
+					errs = append(errs, e)
+				}
+			}
+		}
+
+		for i, n := 0, v.NumField(); i != n; i++ {
+			fv := v.Field(i)
+
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+
+			if fv.Kind() == reflect.Struct {
+				walk(fv)
+			}
+		}
+	}
+
+	walk(v)
+
+	if len(errs) != 0 {
+		err = errs
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package conf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errHostMustNotBeBad = errors.New("host must not be \"bad\"")
+
+type validateTestConfig struct {
+	Host string `conf:"required"`
+	Port int    `conf:"default=8080"`
+}
+
+func TestCheckRequiredReportsMissingFields(t *testing.T) {
+	var c validateTestConfig
+	ld := Loader{Program: "test"}
+
+	_, err := ld.Load(&c)
+	if err == nil {
+		t.Fatal("expected an error for the missing required field")
+	}
+	if !strings.Contains(err.Error(), "Host") {
+		t.Fatalf("expected error to mention the missing field, got: %v", err)
+	}
+}
+
+func TestApplyDefaultsPrecedence(t *testing.T) {
+	var c validateTestConfig
+	ld := Loader{Program: "test", Args: []string{"-Host", "localhost"}}
+
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 8080 {
+		t.Fatalf("expected default port 8080, got %d", c.Port)
+	}
+}
+
+func TestApplyDefaultsOverriddenByArgs(t *testing.T) {
+	var c validateTestConfig
+	ld := Loader{Program: "test", Args: []string{"-Host", "localhost", "-Port", "9090"}}
+
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Port != 9090 {
+		t.Fatalf("expected args to override the default, got %d", c.Port)
+	}
+}
+
+func TestRunValidationInvokesHook(t *testing.T) {
+	var c validatingConfigErr
+	ld := Loader{Program: "test", Args: []string{"-Host", "bad"}}
+
+	_, err := ld.Load(&c)
+	if err == nil {
+		t.Fatal("expected Validate to reject the value")
+	}
+	if !strings.Contains(err.Error(), "host must not be") {
+		t.Fatalf("expected Validate's error message, got: %v", err)
+	}
+}
+
+type validatingConfigErr struct {
+	Host string `conf:"required"`
+}
+
+func (c *validatingConfigErr) Validate() error {
+	if c.Host == "bad" {
+		return errHostMustNotBeBad
+	}
+	return nil
+}
+
+type nestedValidatingConfig struct {
+	Inner validatingConfigErr
+}
+
+func TestRunValidationRecursesIntoNestedStructs(t *testing.T) {
+	var c nestedValidatingConfig
+	ld := Loader{Program: "test", Args: []string{"-Inner.Host", "bad"}}
+
+	_, err := ld.Load(&c)
+	if err == nil {
+		t.Fatal("expected Validate to reject the nested value")
+	}
+}
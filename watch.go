@@ -0,0 +1,112 @@
+package conf
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch monitors the configuration file resolved by the loader's FileFlag
+// and re-runs the file and environment merge (the program arguments stay
+// fixed to the original invocation) whenever that file changes on disk.
+//
+// A reload is atomic: Watch decodes the new configuration into a fresh value
+// of dst's type and runs the same required-field and Validate checks as
+// Load, only swapping the result into dst and calling onChange if that
+// succeeds. dst is therefore never left half-updated, but concurrent readers
+// of dst still need their own synchronization across a swap, for example a
+// sync.RWMutex or a Holder[T] updated from onChange.
+//
+// The returned stop function stops watching the file. Calling it more than
+// once, or after the watcher has failed, is a no-op.
+func (ld Loader) Watch(dst interface{}, onChange func(old, new interface{})) (stop func(), err error) {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("cannot watch configuration in %T", dst))
+	}
+
+	if v.IsNil() {
+		panic(fmt.Sprintf("cannot watch configuration in nil %T", dst))
+	}
+
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("cannot watch configuration in %T", dst))
+	}
+
+	var file string
+
+	if file, err = resolveFilePath(v, ld.Program, ld.FileFlag, ld.DumpFlag, ld.Args, ld.SecretResolvers); err != nil {
+		return
+	}
+
+	if len(file) == 0 {
+		err = fmt.Errorf("conf: cannot watch configuration, no -%s option was set", ld.FileFlag)
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+
+	// Watch the containing directory rather than the file itself so that
+	// editors and config-map updates that replace the file via a rename
+	// still trigger a reload.
+	if err = watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(file) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				ld.reload(v, onChange)
+
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+			case <-done:
+				watcher.Close()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop = func() { once.Do(func() { close(done) }) }
+	return
+}
+
+// reload decodes a fresh configuration value and, only if it's valid, swaps
+// it into v and notifies onChange.
+func (ld Loader) reload(v reflect.Value, onChange func(old, new interface{})) {
+	fresh := reflect.New(v.Type()).Elem()
+
+	if _, err := ld.load(fresh); err != nil {
+		return
+	}
+
+	old := reflect.New(v.Type()).Elem()
+	old.Set(v)
+	v.Set(fresh)
+
+	onChange(old.Addr().Interface(), v.Addr().Interface())
+}
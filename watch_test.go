@@ -0,0 +1,94 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watchTestConfig struct {
+	Host string `json:"host"`
+}
+
+func TestWatchReloadsOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.yaml")
+
+	if err := os.WriteFile(path, []byte("host: localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var c watchTestConfig
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", path}}
+
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	holder := NewHolder(c)
+	changed := make(chan struct{}, 1)
+
+	stop, err := ld.Watch(&c, func(old, new interface{}) {
+		holder.Store(*new.(*watchTestConfig))
+		changed <- struct{}{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	if err := os.WriteFile(path, []byte("host: remotehost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reload to be observed")
+	}
+
+	if got := holder.Load(); got.Host != "remotehost" {
+		t.Fatalf("expected reloaded host %q, got %q", "remotehost", got.Host)
+	}
+}
+
+// The doc comment on Watch's returned stop promises that calling it more
+// than once is a no-op; it must not panic on a repeat call.
+func TestWatchStopIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cfg.yaml")
+
+	if err := os.WriteFile(path, []byte("host: localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var c watchTestConfig
+	ld := Loader{Program: "test", FileFlag: "config-file", Args: []string{"-config-file", path}}
+
+	if _, err := ld.Load(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	stop, err := ld.Watch(&c, func(old, new interface{}) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop()
+	stop()
+}
+
+func TestHolderLoadStore(t *testing.T) {
+	h := NewHolder(watchTestConfig{Host: "localhost"})
+
+	if got := h.Load().Host; got != "localhost" {
+		t.Fatalf("expected %q, got %q", "localhost", got)
+	}
+
+	h.Store(watchTestConfig{Host: "remotehost"})
+
+	if got := h.Load().Host; got != "remotehost" {
+		t.Fatalf("expected %q, got %q", "remotehost", got)
+	}
+}